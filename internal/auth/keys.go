@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// KeyStore holds the HMAC secrets eligible to sign requests, keyed by key
+// ID, so a key can be rotated in by adding it and rotated out by removing
+// it without restarting the process.
+type KeyStore struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+// NewKeyStore returns an empty KeyStore.
+func NewKeyStore() *KeyStore {
+	return &KeyStore{keys: make(map[string][]byte)}
+}
+
+// LoadKeyStoreFile reads a JSON file of the form {"keyId": "hex-secret"}
+// into a new KeyStore.
+func LoadKeyStoreFile(path string) (*KeyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("auth: read key file: %w", err)
+	}
+
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("auth: parse key file: %w", err)
+	}
+
+	ks := NewKeyStore()
+	if err := ks.Replace(raw); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Replace atomically swaps in a new set of hex-encoded secrets, keyed by
+// key ID. This is the entry point for key rotation: call it again with an
+// updated map to add, remove, or change keys.
+func (s *KeyStore) Replace(hexSecrets map[string]string) error {
+	decoded := make(map[string][]byte, len(hexSecrets))
+	for id, hexSecret := range hexSecrets {
+		secret, err := hex.DecodeString(hexSecret)
+		if err != nil {
+			return fmt.Errorf("auth: decode secret for key %q: %w", id, err)
+		}
+		decoded[id] = secret
+	}
+
+	s.mu.Lock()
+	s.keys = decoded
+	s.mu.Unlock()
+	return nil
+}
+
+// Get returns the secret for keyID, if known.
+func (s *KeyStore) Get(keyID string) ([]byte, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	secret, ok := s.keys[keyID]
+	return secret, ok
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKeyID = "test-key"
+
+func testKeyStore(t *testing.T) (*KeyStore, []byte) {
+	t.Helper()
+	secret := []byte("super-secret-hmac-key")
+	ks := NewKeyStore()
+	if err := ks.Replace(map[string]string{testKeyID: fmt.Sprintf("%x", secret)}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	return ks, secret
+}
+
+// signedRequest builds a POST /code request signed with secret, covering
+// headers (in order). Passing a body different from signedBody lets tests
+// simulate a client that signs one body and sends another.
+func signedRequest(t *testing.T, secret []byte, headers []string, date, signedBody, sentBody string) *http.Request {
+	t.Helper()
+
+	sum := sha256.Sum256([]byte(signedBody))
+	digest := "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+
+	r := httptest.NewRequest(http.MethodPost, "/code", strings.NewReader(sentBody))
+	r.Header.Set("Date", date)
+	r.Header.Set("Digest", digest)
+
+	lines := make([]string, 0, len(headers))
+	for _, h := range headers {
+		switch h {
+		case "(request-target)":
+			lines = append(lines, fmt.Sprintf("(request-target): post %s", r.URL.RequestURI()))
+		case "date":
+			lines = append(lines, "date: "+date)
+		case "digest":
+			lines = append(lines, "digest: "+digest)
+		default:
+			t.Fatalf("signedRequest: unsupported header %q", h)
+		}
+	}
+	signingString := strings.Join(lines, "\n")
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingString))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	r.Header.Set("Authorization", fmt.Sprintf(
+		`Signature keyId="%s",algorithm="hmac-sha256",headers="%s",signature="%s"`,
+		testKeyID, strings.Join(headers, " "), signature,
+	))
+	return r
+}
+
+func verify(t *testing.T, v *Verifier, r *http.Request) int {
+	t.Helper()
+	w := httptest.NewRecorder()
+	v.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})).ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestVerifierAcceptsValidSignature(t *testing.T) {
+	ks, secret := testKeyStore(t)
+	v := NewVerifier(ks)
+
+	r := signedRequest(t, secret, []string{"(request-target)", "date", "digest"}, time.Now().UTC().Format(http.TimeFormat), `{"files":[]}`, `{"files":[]}`)
+	if code := verify(t, v, r); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+}
+
+func TestVerifierRejectsTamperedBody(t *testing.T) {
+	ks, secret := testKeyStore(t)
+	v := NewVerifier(ks)
+
+	r := signedRequest(t, secret, []string{"(request-target)", "date", "digest"}, time.Now().UTC().Format(http.TimeFormat), `{"files":[]}`, `{"files":["evil"]}`)
+	if code := verify(t, v, r); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for tampered body, got %d", code)
+	}
+}
+
+func TestVerifierRejectsStaleDate(t *testing.T) {
+	ks, secret := testKeyStore(t)
+	v := NewVerifier(ks)
+
+	stale := time.Now().Add(-1 * time.Hour).UTC().Format(http.TimeFormat)
+	r := signedRequest(t, secret, []string{"(request-target)", "date", "digest"}, stale, `{"files":[]}`, `{"files":[]}`)
+	if code := verify(t, v, r); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for stale date, got %d", code)
+	}
+}
+
+func TestVerifierRejectsUnknownKeyID(t *testing.T) {
+	ks, secret := testKeyStore(t)
+	v := NewVerifier(ks)
+
+	r := signedRequest(t, secret, []string{"(request-target)", "date", "digest"}, time.Now().UTC().Format(http.TimeFormat), `{"files":[]}`, `{"files":[]}`)
+	r.Header.Set("Authorization", strings.Replace(r.Header.Get("Authorization"), testKeyID, "other-key", 1))
+	if code := verify(t, v, r); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for unknown keyId, got %d", code)
+	}
+}
+
+// TestVerifierRejectsPartialHeaderCoverage guards against the replay hole
+// where a signature that doesn't cover date/digest lets an attacker reuse
+// one captured Authorization header with an arbitrary new body and a
+// freshly stamped Date.
+func TestVerifierRejectsPartialHeaderCoverage(t *testing.T) {
+	ks, secret := testKeyStore(t)
+	v := NewVerifier(ks)
+
+	r := signedRequest(t, secret, []string{"(request-target)"}, time.Now().UTC().Format(http.TimeFormat), `{"files":[]}`, `{"files":[]}`)
+	if code := verify(t, v, r); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 when signature doesn't cover date/digest, got %d", code)
+	}
+}
+
+func TestVerifierOptionalWhenUnsigned(t *testing.T) {
+	ks, _ := testKeyStore(t)
+	v := NewVerifier(ks)
+
+	r := httptest.NewRequest(http.MethodPost, "/code", strings.NewReader(`{"files":[]}`))
+	if code := verify(t, v, r); code != http.StatusOK {
+		t.Fatalf("expected unsigned request to pass through when not required, got %d", code)
+	}
+}
+
+func TestVerifierRequiredRejectsUnsigned(t *testing.T) {
+	ks, _ := testKeyStore(t)
+	v := NewVerifier(ks)
+	v.Required = true
+
+	r := httptest.NewRequest(http.MethodPost, "/code", strings.NewReader(`{"files":[]}`))
+	if code := verify(t, v, r); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for unsigned request when required, got %d", code)
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// signatureParams is the parsed content of an
+// `Authorization: Signature keyId="...",algorithm="...",headers="...",signature="..."`
+// header.
+type signatureParams struct {
+	keyID     string
+	algorithm string
+	headers   []string
+	signature string
+}
+
+var signatureFieldRe = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// parseSignatureHeader parses the value of an Authorization header whose
+// scheme is "Signature", as used by saltyim's SignedRequest scheme.
+func parseSignatureHeader(value string) (*signatureParams, error) {
+	const prefix = "Signature "
+	if !strings.HasPrefix(value, prefix) {
+		return nil, fmt.Errorf("auth: unsupported Authorization scheme")
+	}
+
+	fields := make(map[string]string)
+	for _, m := range signatureFieldRe.FindAllStringSubmatch(value[len(prefix):], -1) {
+		fields[m[1]] = m[2]
+	}
+
+	params := &signatureParams{
+		keyID:     fields["keyId"],
+		algorithm: fields["algorithm"],
+		signature: fields["signature"],
+	}
+	if params.keyID == "" || params.signature == "" {
+		return nil, fmt.Errorf("auth: signature header missing keyId or signature")
+	}
+	if params.algorithm == "" {
+		params.algorithm = "hmac-sha256"
+	}
+	if h := fields["headers"]; h != "" {
+		params.headers = strings.Fields(h)
+	} else {
+		params.headers = []string{"(request-target)"}
+	}
+	return params, nil
+}
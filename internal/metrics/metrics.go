@@ -0,0 +1,45 @@
+// Package metrics holds the Prometheus collectors for the reviewer
+// subsystem so the /code handler and the provider clients can observe the
+// same registry that the introspection server exposes on /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// CodeRequestsTotal counts /code requests by outcome ("ok" or "error").
+	CodeRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "autocritic_code_requests_total",
+		Help: "Total number of /code requests handled, by outcome.",
+	}, []string{"outcome"})
+
+	// CodeRequestDuration is the latency of /code requests in seconds.
+	CodeRequestDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "autocritic_code_request_duration_seconds",
+		Help:    "Latency of /code requests in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// CodeRequestsInFlight is the number of /code requests currently
+	// being handled.
+	CodeRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "autocritic_code_requests_in_flight",
+		Help: "Number of /code requests currently being handled.",
+	})
+
+	// ProviderTokensTotal counts tokens reported by provider responses,
+	// labeled by provider and token kind ("prompt" or "completion").
+	ProviderTokensTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "autocritic_provider_tokens_total",
+		Help: "Total tokens reported by AI providers, by provider and kind.",
+	}, []string{"provider", "kind"})
+
+	// ProviderErrorsTotal counts failed provider calls, labeled by
+	// provider.
+	ProviderErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "autocritic_provider_errors_total",
+		Help: "Total failed AI provider calls, by provider.",
+	}, []string{"provider"})
+)
@@ -0,0 +1,90 @@
+package reviewer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lemon-mint/autocritic/internal/metrics"
+)
+
+// anthropicReviewer talks to the Anthropic Messages API.
+type anthropicReviewer struct {
+	client  *httpClient
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+// NewAnthropicReviewer builds a Reviewer backed by the Anthropic Messages
+// API. cfg.BaseURL defaults to the official API.
+func NewAnthropicReviewer(cfg Config) Reviewer {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.anthropic.com/v1"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "claude-3-5-sonnet-latest"
+	}
+	return &anthropicReviewer{
+		client:  newHTTPClient(cfg.Timeout, cfg.MaxRetries),
+		baseURL: baseURL,
+		apiKey:  cfg.APIKey,
+		model:   model,
+	}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicMessagesRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system"`
+	Messages  []anthropicMessage `json:"messages"`
+	MaxTokens int                `json:"max_tokens"`
+}
+
+type anthropicMessagesResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func (r *anthropicReviewer) Review(ctx context.Context, req CodeRequest) (*CodeResponse, error) {
+	body := anthropicMessagesRequest{
+		Model:     r.model,
+		System:    systemPrompt,
+		MaxTokens: 4096,
+		Messages: []anthropicMessage{
+			{Role: "user", Content: buildUserPrompt(req)},
+		},
+	}
+
+	var resp anthropicMessagesResponse
+	headers := map[string]string{
+		"Content-Type":      "application/json",
+		"X-Api-Key":         r.apiKey,
+		"Anthropic-Version": "2023-06-01",
+	}
+	if err := r.client.doJSON(ctx, "POST", r.baseURL+"/messages", headers, body, &resp); err != nil {
+		metrics.ProviderErrorsTotal.WithLabelValues("anthropic").Inc()
+		return nil, err
+	}
+	metrics.ProviderTokensTotal.WithLabelValues("anthropic", "prompt").Add(float64(resp.Usage.InputTokens))
+	metrics.ProviderTokensTotal.WithLabelValues("anthropic", "completion").Add(float64(resp.Usage.OutputTokens))
+
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			return parseCodeResponse(block.Text)
+		}
+	}
+	metrics.ProviderErrorsTotal.WithLabelValues("anthropic").Inc()
+	return nil, fmt.Errorf("reviewer: anthropic: response had no text content")
+}
@@ -0,0 +1,84 @@
+package reviewer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lemon-mint/autocritic/internal/metrics"
+)
+
+// geminiReviewer talks to the Google Gemini generateContent API.
+type geminiReviewer struct {
+	client  *httpClient
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+// NewGeminiReviewer builds a Reviewer backed by the Google Gemini
+// generateContent API. cfg.BaseURL defaults to the official API.
+func NewGeminiReviewer(cfg Config) Reviewer {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://generativelanguage.googleapis.com/v1beta"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "gemini-1.5-flash"
+	}
+	return &geminiReviewer{
+		client:  newHTTPClient(cfg.Timeout, cfg.MaxRetries),
+		baseURL: baseURL,
+		apiKey:  cfg.APIKey,
+		model:   model,
+	}
+}
+
+type geminiPart struct {
+	Text string `json:"text"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiGenerateRequest struct {
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	Contents          []geminiContent `json:"contents"`
+}
+
+type geminiGenerateResponse struct {
+	Candidates []struct {
+		Content geminiContent `json:"content"`
+	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+func (r *geminiReviewer) Review(ctx context.Context, req CodeRequest) (*CodeResponse, error) {
+	body := geminiGenerateRequest{
+		SystemInstruction: &geminiContent{Parts: []geminiPart{{Text: systemPrompt}}},
+		Contents: []geminiContent{
+			{Role: "user", Parts: []geminiPart{{Text: buildUserPrompt(req)}}},
+		},
+	}
+
+	var resp geminiGenerateResponse
+	url := fmt.Sprintf("%s/models/%s:generateContent?key=%s", r.baseURL, r.model, r.apiKey)
+	headers := map[string]string{"Content-Type": "application/json"}
+	if err := r.client.doJSON(ctx, "POST", url, headers, body, &resp); err != nil {
+		metrics.ProviderErrorsTotal.WithLabelValues("gemini").Inc()
+		return nil, err
+	}
+	metrics.ProviderTokensTotal.WithLabelValues("gemini", "prompt").Add(float64(resp.UsageMetadata.PromptTokenCount))
+	metrics.ProviderTokensTotal.WithLabelValues("gemini", "completion").Add(float64(resp.UsageMetadata.CandidatesTokenCount))
+
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		metrics.ProviderErrorsTotal.WithLabelValues("gemini").Inc()
+		return nil, fmt.Errorf("reviewer: gemini: response had no candidates")
+	}
+	return parseCodeResponse(resp.Candidates[0].Content.Parts[0].Text)
+}
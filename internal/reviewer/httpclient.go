@@ -0,0 +1,173 @@
+package reviewer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// credentialQueryParams are URL query parameters that carry secrets for at
+// least one provider (Gemini's API key is passed as ?key=...) and must
+// never reach logs or error messages verbatim.
+var credentialQueryParams = []string{"key"}
+
+// redactURL returns rawURL with any credential-bearing query parameters
+// replaced by "REDACTED", so it is safe to log or include in error text.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	redacted := false
+	for _, param := range credentialQueryParams {
+		if q.Has(param) {
+			q.Set(param, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return rawURL
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// httpClient is a small wrapper shared by the provider implementations. It
+// adds configurable timeouts, exponential-backoff retries on 429/5xx that
+// honor a Retry-After header, and request/response body dumping at debug
+// log level so prompt failures can be diagnosed.
+type httpClient struct {
+	hc         *http.Client
+	maxRetries int
+}
+
+func newHTTPClient(timeout time.Duration, maxRetries int) *httpClient {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	return &httpClient{
+		hc:         &http.Client{Timeout: timeout},
+		maxRetries: maxRetries,
+	}
+}
+
+// doJSON marshals body (if non-nil) as the request payload, sends method to
+// url with headers, retries on 429/5xx, and unmarshals the response into
+// out (if non-nil).
+func (c *httpClient) doJSON(ctx context.Context, method, url string, headers map[string]string, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("reviewer: marshal request: %w", err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("reviewer: build request: %w", err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+
+		log.Debug().Str("url", redactURL(url)).Int("attempt", attempt).RawJSON("request_body", payload).Msg("reviewer: dispatching request")
+
+		resp, err := c.hc.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("reviewer: do request: %w", err)
+			if ctx.Err() != nil {
+				return lastErr
+			}
+			if !sleepBackoff(ctx, attempt, 0) {
+				return lastErr
+			}
+			continue
+		}
+
+		respBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return fmt.Errorf("reviewer: read response: %w", readErr)
+		}
+
+		log.Debug().Str("url", redactURL(url)).Int("status", resp.StatusCode).RawJSON("response_body", respBody).Msg("reviewer: received response")
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("reviewer: %s returned status %d: %s", redactURL(url), resp.StatusCode, respBody)
+			if attempt == c.maxRetries {
+				return lastErr
+			}
+			if !sleepBackoff(ctx, attempt, retryAfter(resp.Header.Get("Retry-After"))) {
+				return lastErr
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("reviewer: %s returned status %d: %s", redactURL(url), resp.StatusCode, respBody)
+		}
+
+		if out != nil {
+			if err := json.Unmarshal(respBody, out); err != nil {
+				return fmt.Errorf("reviewer: unmarshal response: %w", err)
+			}
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// sleepBackoff waits out an exponential backoff (doubling from 500ms, capped
+// at 30s) for attempt, or minDelay if it is longer. It returns false if ctx
+// is done before or during the wait.
+func sleepBackoff(ctx context.Context, attempt int, minDelay time.Duration) bool {
+	delay := time.Duration(float64(500*time.Millisecond) * math.Pow(2, float64(attempt)))
+	if delay > 30*time.Second {
+		delay = 30 * time.Second
+	}
+	if minDelay > delay {
+		delay = minDelay
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retryAfter parses a Retry-After header value, which may be a number of
+// seconds or an HTTP-date. It returns 0 if the header is empty or invalid.
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package reviewer
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterSeconds(t *testing.T) {
+	if got := retryAfter("5"); got != 5*time.Second {
+		t.Fatalf("retryAfter(\"5\") = %v, want 5s", got)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	got := retryAfter(future.Format(http.TimeFormat))
+	if got <= 0 || got > 10*time.Second {
+		t.Fatalf("retryAfter(future HTTP-date) = %v, want a positive duration up to 10s", got)
+	}
+}
+
+func TestRetryAfterEmptyOrInvalid(t *testing.T) {
+	if got := retryAfter(""); got != 0 {
+		t.Fatalf("retryAfter(\"\") = %v, want 0", got)
+	}
+	if got := retryAfter("not-a-valid-value"); got != 0 {
+		t.Fatalf("retryAfter(invalid) = %v, want 0", got)
+	}
+}
+
+func TestRetryAfterPastHTTPDate(t *testing.T) {
+	past := time.Now().Add(-1 * time.Hour).UTC().Format(http.TimeFormat)
+	if got := retryAfter(past); got != 0 {
+		t.Fatalf("retryAfter(past date) = %v, want 0", got)
+	}
+}
+
+func TestRedactURLStripsCredentialParam(t *testing.T) {
+	got := redactURL("https://generativelanguage.googleapis.com/v1/models?key=super-secret")
+	if got == "https://generativelanguage.googleapis.com/v1/models?key=super-secret" {
+		t.Fatal("expected the key query param to be redacted")
+	}
+	if want := "key=REDACTED"; !strings.Contains(got, want) {
+		t.Fatalf("redactURL = %q, want it to contain %q", got, want)
+	}
+}
+
+func TestRedactURLPreservesOtherParams(t *testing.T) {
+	got := redactURL("https://example.com/v1?key=secret&alt=sse")
+	if !strings.Contains(got, "alt=sse") {
+		t.Fatalf("redactURL = %q, want it to preserve the alt param", got)
+	}
+}
+
+func TestRedactURLNoOpWithoutCredentialParam(t *testing.T) {
+	url := "https://api.openai.com/v1/chat/completions"
+	if got := redactURL(url); got != url {
+		t.Fatalf("redactURL(%q) = %q, want it unchanged", url, got)
+	}
+}
+
+func TestSleepBackoffReturnsFalseOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if sleepBackoff(ctx, 0, 0) {
+		t.Fatal("expected sleepBackoff to return false for an already-canceled context")
+	}
+}
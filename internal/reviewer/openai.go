@@ -0,0 +1,84 @@
+package reviewer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lemon-mint/autocritic/internal/metrics"
+)
+
+// openAIReviewer talks to any OpenAI-compatible chat completions API.
+type openAIReviewer struct {
+	client  *httpClient
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+// NewOpenAIReviewer builds a Reviewer backed by an OpenAI-compatible
+// /chat/completions endpoint. cfg.BaseURL defaults to the official API.
+func NewOpenAIReviewer(cfg Config) Reviewer {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	model := cfg.Model
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+	return &openAIReviewer{
+		client:  newHTTPClient(cfg.Timeout, cfg.MaxRetries),
+		baseURL: baseURL,
+		apiKey:  cfg.APIKey,
+		model:   model,
+	}
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model    string              `json:"model"`
+	Messages []openAIChatMessage `json:"messages"`
+	Stream   bool                `json:"stream,omitempty"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIChatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func (r *openAIReviewer) Review(ctx context.Context, req CodeRequest) (*CodeResponse, error) {
+	body := openAIChatRequest{
+		Model: r.model,
+		Messages: []openAIChatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: buildUserPrompt(req)},
+		},
+	}
+
+	var resp openAIChatResponse
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + r.apiKey,
+	}
+	if err := r.client.doJSON(ctx, "POST", r.baseURL+"/chat/completions", headers, body, &resp); err != nil {
+		metrics.ProviderErrorsTotal.WithLabelValues("openai").Inc()
+		return nil, err
+	}
+	metrics.ProviderTokensTotal.WithLabelValues("openai", "prompt").Add(float64(resp.Usage.PromptTokens))
+	metrics.ProviderTokensTotal.WithLabelValues("openai", "completion").Add(float64(resp.Usage.CompletionTokens))
+
+	if len(resp.Choices) == 0 {
+		metrics.ProviderErrorsTotal.WithLabelValues("openai").Inc()
+		return nil, fmt.Errorf("reviewer: openai: response had no choices")
+	}
+	return parseCodeResponse(resp.Choices[0].Message.Content)
+}
@@ -0,0 +1,136 @@
+package reviewer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/lemon-mint/autocritic/internal/metrics"
+)
+
+// openAIChatStreamChunk is one `data: {...}` line of an OpenAI
+// chat-completions SSE stream.
+type openAIChatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// ReviewStream implements StreamingReviewer by requesting the upstream
+// chat-completions call with "stream": true and relaying each token delta
+// as it arrives. Since the model is instructed to reply with a single JSON
+// object, structured findings can only be parsed once the full text has
+// been accumulated, so "finding" events are emitted after the stream ends
+// rather than as each token arrives.
+func (r *openAIReviewer) ReviewStream(ctx context.Context, req CodeRequest) (<-chan ReviewEvent, error) {
+	body := openAIChatRequest{
+		Model:  r.model,
+		Stream: true,
+		Messages: []openAIChatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: buildUserPrompt(req)},
+		},
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("reviewer: marshal stream request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", r.baseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("reviewer: build stream request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+r.apiKey)
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	resp, err := r.client.hc.Do(httpReq)
+	if err != nil {
+		metrics.ProviderErrorsTotal.WithLabelValues("openai").Inc()
+		return nil, fmt.Errorf("reviewer: openai: stream request: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		errBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		metrics.ProviderErrorsTotal.WithLabelValues("openai").Inc()
+		return nil, fmt.Errorf("reviewer: openai: stream request returned status %d: %s", resp.StatusCode, errBody)
+	}
+
+	events := make(chan ReviewEvent)
+	go r.relayStream(ctx, resp.Body, events)
+	return events, nil
+}
+
+func (r *openAIReviewer) relayStream(ctx context.Context, body io.ReadCloser, events chan<- ReviewEvent) {
+	defer close(events)
+	defer body.Close()
+
+	var full strings.Builder
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+		if data == "[DONE]" {
+			continue
+		}
+
+		var chunk openAIChatStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		full.WriteString(delta)
+		select {
+		case events <- ReviewEvent{Kind: EventToken, Token: delta}:
+		case <-ctx.Done():
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		metrics.ProviderErrorsTotal.WithLabelValues("openai").Inc()
+		select {
+		case events <- ReviewEvent{Kind: EventError, Error: err.Error()}:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	resp, err := parseCodeResponse(full.String())
+	if err != nil {
+		metrics.ProviderErrorsTotal.WithLabelValues("openai").Inc()
+		select {
+		case events <- ReviewEvent{Kind: EventError, Error: err.Error()}:
+		case <-ctx.Done():
+		}
+		return
+	}
+
+	for i := range resp.Findings {
+		finding := resp.Findings[i]
+		select {
+		case events <- ReviewEvent{Kind: EventFinding, Finding: &finding}:
+		case <-ctx.Done():
+			return
+		}
+	}
+	select {
+	case events <- ReviewEvent{Kind: EventDone}:
+	case <-ctx.Done():
+	}
+}
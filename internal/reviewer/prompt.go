@@ -0,0 +1,43 @@
+package reviewer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// systemPrompt instructs the model to answer as a JSON object matching
+// CodeResponse, which every provider implementation parses the same way.
+const systemPrompt = `You are an automated code reviewer. Review the submitted files and ` +
+	`respond with a single JSON object of the form ` +
+	`{"findings":[{"file":"...","start_line":0,"end_line":0,"severity":"info|warning|error|critical","category":"...","message":"...","suggested_patch":"..."}]}. ` +
+	`Respond with JSON only, no surrounding prose.`
+
+// buildUserPrompt renders req as the user-turn content sent to the model.
+func buildUserPrompt(req CodeRequest) string {
+	var b strings.Builder
+	for _, f := range req.Files {
+		lang := f.Language
+		if lang == "" {
+			lang = "text"
+		}
+		fmt.Fprintf(&b, "File: %s\n```%s\n%s\n```\n\n", f.Path, lang, f.Content)
+	}
+	return b.String()
+}
+
+// parseCodeResponse extracts the JSON object described by systemPrompt out
+// of a model's raw text reply, tolerating ```json fenced code blocks.
+func parseCodeResponse(text string) (*CodeResponse, error) {
+	text = strings.TrimSpace(text)
+	text = strings.TrimPrefix(text, "```json")
+	text = strings.TrimPrefix(text, "```")
+	text = strings.TrimSuffix(text, "```")
+	text = strings.TrimSpace(text)
+
+	var out CodeResponse
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		return nil, fmt.Errorf("reviewer: parse model response: %w", err)
+	}
+	return &out, nil
+}
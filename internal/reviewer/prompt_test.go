@@ -0,0 +1,39 @@
+package reviewer
+
+import "testing"
+
+func TestParseCodeResponseBareJSON(t *testing.T) {
+	resp, err := parseCodeResponse(`{"findings":[{"file":"a.go","severity":"warning","category":"style","message":"nit"}]}`)
+	if err != nil {
+		t.Fatalf("parseCodeResponse: %v", err)
+	}
+	if len(resp.Findings) != 1 || resp.Findings[0].File != "a.go" {
+		t.Fatalf("unexpected findings: %+v", resp.Findings)
+	}
+}
+
+func TestParseCodeResponseFencedWithLanguageTag(t *testing.T) {
+	resp, err := parseCodeResponse("```json\n{\"findings\":[]}\n```")
+	if err != nil {
+		t.Fatalf("parseCodeResponse: %v", err)
+	}
+	if len(resp.Findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", resp.Findings)
+	}
+}
+
+func TestParseCodeResponseFencedWithoutLanguageTag(t *testing.T) {
+	resp, err := parseCodeResponse("```\n{\"findings\":[]}\n```")
+	if err != nil {
+		t.Fatalf("parseCodeResponse: %v", err)
+	}
+	if len(resp.Findings) != 0 {
+		t.Fatalf("expected no findings, got %+v", resp.Findings)
+	}
+}
+
+func TestParseCodeResponseInvalidJSON(t *testing.T) {
+	if _, err := parseCodeResponse("not json"); err == nil {
+		t.Fatal("expected an error for invalid JSON")
+	}
+}
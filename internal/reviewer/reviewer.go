@@ -0,0 +1,95 @@
+// Package reviewer defines the provider-agnostic contract for submitting
+// code to an AI backend for review and the structured findings it returns.
+package reviewer
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Severity is how serious a Finding is, in increasing order of importance.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+// FileInput is a single file submitted for review. Language is a hint
+// (e.g. "go", "python") used to label the file in the prompt; it is
+// optional, and when empty the file is labelled "text" (no language is
+// inferred from Path).
+type FileInput struct {
+	Path     string `json:"path"`
+	Language string `json:"language,omitempty"`
+	Content  string `json:"content"`
+}
+
+// CodeRequest is a multi-file review request.
+type CodeRequest struct {
+	Files []FileInput `json:"files"`
+}
+
+// Finding is a single structured observation about a reviewed file.
+type Finding struct {
+	File           string   `json:"file"`
+	StartLine      int      `json:"start_line,omitempty"`
+	EndLine        int      `json:"end_line,omitempty"`
+	Severity       Severity `json:"severity"`
+	Category       string   `json:"category"`
+	Message        string   `json:"message"`
+	SuggestedPatch string   `json:"suggested_patch,omitempty"`
+}
+
+// CodeResponse is the structured result of a review.
+type CodeResponse struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Reviewer reviews submitted code and returns structured findings.
+type Reviewer interface {
+	Review(ctx context.Context, req CodeRequest) (*CodeResponse, error)
+}
+
+// Config configures a provider-backed Reviewer. Which fields are required
+// depends on Provider; see the individual New*Reviewer constructors.
+type Config struct {
+	// Provider selects the backend: "openai", "anthropic", or "gemini".
+	Provider string
+	APIKey   string
+	// BaseURL overrides the provider's default API endpoint, mainly for
+	// OpenAI-compatible third-party hosts.
+	BaseURL string
+	Model   string
+
+	// Timeout bounds a single HTTP call, not the whole review including
+	// retries. Zero means DefaultTimeout.
+	Timeout time.Duration
+	// MaxRetries is how many additional attempts are made after a 429 or
+	// 5xx response. Zero means DefaultMaxRetries.
+	MaxRetries int
+}
+
+// DefaultTimeout and DefaultMaxRetries are used when a Config leaves the
+// corresponding field at its zero value.
+const (
+	DefaultTimeout    = 30 * time.Second
+	DefaultMaxRetries = 3
+)
+
+// New builds the Reviewer selected by cfg.Provider.
+func New(cfg Config) (Reviewer, error) {
+	switch cfg.Provider {
+	case "openai":
+		return NewOpenAIReviewer(cfg), nil
+	case "anthropic":
+		return NewAnthropicReviewer(cfg), nil
+	case "gemini":
+		return NewGeminiReviewer(cfg), nil
+	default:
+		return nil, fmt.Errorf("reviewer: unknown provider %q", cfg.Provider)
+	}
+}
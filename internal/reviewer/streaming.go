@@ -0,0 +1,77 @@
+package reviewer
+
+import "context"
+
+// ReviewEventKind identifies what kind of update a ReviewEvent carries.
+type ReviewEventKind string
+
+const (
+	EventToken   ReviewEventKind = "token"
+	EventFinding ReviewEventKind = "finding"
+	EventDone    ReviewEventKind = "done"
+	EventError   ReviewEventKind = "error"
+)
+
+// ReviewEvent is one incremental update from a StreamingReviewer.
+type ReviewEvent struct {
+	Kind    ReviewEventKind `json:"kind"`
+	Token   string          `json:"token,omitempty"`
+	Finding *Finding        `json:"finding,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// StreamingReviewer reviews code and emits incremental ReviewEvents as the
+// upstream LLM produces them. The returned channel is closed after a done
+// or error event has been sent, or ctx is canceled.
+type StreamingReviewer interface {
+	ReviewStream(ctx context.Context, req CodeRequest) (<-chan ReviewEvent, error)
+}
+
+// asStreamingReviewer adapts a plain Reviewer into a StreamingReviewer by
+// running the full review and replaying its findings one event at a time.
+// Providers that can stream tokens natively from the upstream API should
+// implement StreamingReviewer directly instead of relying on this adapter.
+type asStreamingReviewer struct {
+	Reviewer
+}
+
+// AsStreaming returns r as a StreamingReviewer, using r's native
+// implementation if it has one and falling back to asStreamingReviewer
+// otherwise.
+func AsStreaming(r Reviewer) StreamingReviewer {
+	if sr, ok := r.(StreamingReviewer); ok {
+		return sr
+	}
+	return &asStreamingReviewer{r}
+}
+
+func (a *asStreamingReviewer) ReviewStream(ctx context.Context, req CodeRequest) (<-chan ReviewEvent, error) {
+	events := make(chan ReviewEvent)
+	go func() {
+		defer close(events)
+
+		resp, err := a.Review(ctx, req)
+		if err != nil {
+			select {
+			case events <- ReviewEvent{Kind: EventError, Error: err.Error()}:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		for i := range resp.Findings {
+			finding := resp.Findings[i]
+			select {
+			case events <- ReviewEvent{Kind: EventFinding, Finding: &finding}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case events <- ReviewEvent{Kind: EventDone}:
+		case <-ctx.Done():
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/lemon-mint/autocritic/internal/reviewer"
+)
+
+// KeyResolver returns the API credential to use for provider, or "" if it
+// has none configured.
+type KeyResolver func(provider string) string
+
+// Dispatcher reviews each file in a CodeRequest through its matching rule
+// chain from Store, building a Reviewer per provider/model override on
+// demand, and aggregates the resulting findings. It implements
+// reviewer.Reviewer, so it can be used anywhere a plain Reviewer is
+// expected.
+type Dispatcher struct {
+	store     *Store
+	baseCfg   reviewer.Config
+	def       reviewer.Reviewer
+	apiKeyFor KeyResolver
+
+	mu        sync.Mutex
+	reviewers map[string]reviewer.Reviewer
+}
+
+// NewDispatcher returns a Dispatcher that routes files through store's
+// rules. baseCfg supplies the HTTP tuning and fallback credential shared by
+// every provider/model a rule selects; apiKeyFor resolves the credential for
+// a specific provider and takes precedence over baseCfg.APIKey when it
+// returns a non-empty value (pass nil to always use baseCfg.APIKey). def is
+// used for files whose matching rules set neither a provider nor a model.
+func NewDispatcher(store *Store, baseCfg reviewer.Config, def reviewer.Reviewer, apiKeyFor KeyResolver) *Dispatcher {
+	return &Dispatcher{
+		store:     store,
+		baseCfg:   baseCfg,
+		def:       def,
+		apiKeyFor: apiKeyFor,
+		reviewers: make(map[string]reviewer.Reviewer),
+	}
+}
+
+// Review implements reviewer.Reviewer.
+func (d *Dispatcher) Review(ctx context.Context, req reviewer.CodeRequest) (*reviewer.CodeResponse, error) {
+	set := d.store.Current()
+	var out reviewer.CodeResponse
+
+	for _, file := range req.Files {
+		chain := set.Match(file)
+		rev, promptTemplate, minSeverity := d.resolve(chain)
+
+		fileReq := reviewer.CodeRequest{Files: []reviewer.FileInput{file}}
+		if promptTemplate != "" {
+			fileReq.Files[0].Content = promptTemplate + "\n\n" + file.Content
+		}
+
+		resp, err := rev.Review(ctx, fileReq)
+		if err != nil {
+			return nil, fmt.Errorf("rules: review %s: %w", file.Path, err)
+		}
+		for _, finding := range resp.Findings {
+			if meetsThreshold(finding.Severity, reviewer.Severity(minSeverity)) {
+				out.Findings = append(out.Findings, finding)
+			}
+		}
+	}
+	return &out, nil
+}
+
+// resolve folds a rule chain into the Reviewer, prompt template, and
+// minimum severity that apply, with later rules overriding earlier ones.
+func (d *Dispatcher) resolve(chain []Rule) (rev reviewer.Reviewer, promptTemplate, minSeverity string) {
+	var provider, model string
+	for _, r := range chain {
+		if r.Provider != "" {
+			provider = r.Provider
+		}
+		if r.Model != "" {
+			model = r.Model
+		}
+		if r.PromptTemplate != "" {
+			promptTemplate = r.PromptTemplate
+		}
+		if r.MinSeverity != "" {
+			minSeverity = r.MinSeverity
+		}
+	}
+
+	// A rule may override Model alone, leaving Provider unset; that still
+	// needs to switch models on the deployment's default provider rather
+	// than being silently dropped.
+	if provider == "" {
+		if model == "" {
+			return d.def, promptTemplate, minSeverity
+		}
+		provider = d.baseCfg.Provider
+	}
+	return d.reviewerFor(provider, model), promptTemplate, minSeverity
+}
+
+// reviewerFor returns a cached Reviewer for provider/model, building and
+// caching one from baseCfg on first use.
+func (d *Dispatcher) reviewerFor(provider, model string) reviewer.Reviewer {
+	key := provider + "/" + model
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if rev, ok := d.reviewers[key]; ok {
+		return rev
+	}
+
+	cfg := d.baseCfg
+	cfg.Provider = provider
+	cfg.Model = model
+	if d.apiKeyFor != nil {
+		if key := d.apiKeyFor(provider); key != "" {
+			cfg.APIKey = key
+		}
+	}
+	rev, err := reviewer.New(cfg)
+	if err != nil {
+		log.Error().Err(err).Str("provider", provider).Str("model", model).Msg("rules: falling back to default reviewer")
+		rev = d.def
+	}
+	d.reviewers[key] = rev
+	return rev
+}
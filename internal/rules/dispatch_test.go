@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lemon-mint/autocritic/internal/reviewer"
+)
+
+// stubReviewer ignores the request it receives and returns a fixed
+// response, standing in for a real provider-backed Reviewer in tests.
+type stubReviewer struct {
+	resp *reviewer.CodeResponse
+}
+
+func (s *stubReviewer) Review(ctx context.Context, req reviewer.CodeRequest) (*reviewer.CodeResponse, error) {
+	return s.resp, nil
+}
+
+func TestDispatcherResolveChainOverridePrecedence(t *testing.T) {
+	def := &stubReviewer{}
+	d := NewDispatcher(nil, reviewer.Config{}, def, nil)
+
+	chain := []Rule{
+		{PromptTemplate: "base template", MinSeverity: "info"},
+		{PromptTemplate: "override template", Provider: "openai", Model: "gpt-4o-mini"},
+	}
+
+	rev, promptTemplate, minSeverity := d.resolve(chain)
+	if promptTemplate != "override template" {
+		t.Fatalf("expected later rule's prompt_template to win, got %q", promptTemplate)
+	}
+	if minSeverity != "info" {
+		t.Fatalf("expected earlier rule's min_severity to survive since the later rule left it unset, got %q", minSeverity)
+	}
+	if rev == def {
+		t.Fatalf("expected a provider-specific reviewer, got the default")
+	}
+}
+
+func TestDispatcherResolveNoMatchUsesDefault(t *testing.T) {
+	def := &stubReviewer{}
+	d := NewDispatcher(nil, reviewer.Config{}, def, nil)
+
+	rev, _, _ := d.resolve(nil)
+	if rev != def {
+		t.Fatalf("expected the default reviewer when no rule sets a provider or model")
+	}
+}
+
+func TestDispatcherResolveModelOnlyOverrideUsesDefaultProvider(t *testing.T) {
+	def := &stubReviewer{}
+	d := NewDispatcher(nil, reviewer.Config{Provider: "openai"}, def, nil)
+
+	rev, _, _ := d.resolve([]Rule{{Model: "gpt-4o"}})
+	if rev == def {
+		t.Fatalf("expected a model override to build a reviewer on the default provider, got the default reviewer")
+	}
+	if rev != d.reviewerFor("openai", "gpt-4o") {
+		t.Fatalf("expected the model override to resolve against the baseCfg provider")
+	}
+}
+
+func TestDispatcherReviewerForUsesResolvedAPIKey(t *testing.T) {
+	var gotKey string
+	d := NewDispatcher(nil, reviewer.Config{Provider: "openai", APIKey: "fallback-key"}, &stubReviewer{}, func(provider string) string {
+		if provider == "anthropic" {
+			gotKey = "anthropic-key"
+			return gotKey
+		}
+		return ""
+	})
+
+	d.reviewerFor("anthropic", "claude-3")
+	if gotKey != "anthropic-key" {
+		t.Fatalf("expected apiKeyFor to be consulted for the anthropic provider")
+	}
+}
+
+func TestDispatcherReviewerForCachesByProviderAndModel(t *testing.T) {
+	d := NewDispatcher(nil, reviewer.Config{Provider: "openai"}, &stubReviewer{}, nil)
+
+	a := d.reviewerFor("openai", "gpt-4o-mini")
+	b := d.reviewerFor("openai", "gpt-4o-mini")
+	if a != b {
+		t.Fatalf("expected reviewerFor to cache by provider/model key")
+	}
+
+	c := d.reviewerFor("openai", "gpt-4o")
+	if a == c {
+		t.Fatalf("expected a different model to build a distinct reviewer")
+	}
+}
@@ -0,0 +1,104 @@
+// Package rules implements a regexp/glob-routed review policy engine:
+// operators declare rules that match submitted files on path, language, or
+// content, each selecting a prompt template, provider, model, and severity
+// threshold for the files it matches.
+package rules
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/lemon-mint/autocritic/internal/reviewer"
+)
+
+// Rule is one entry in a rule set. A file matches a Rule when every
+// non-empty matcher field (PathGlob, Language, ContentPattern) matches it.
+type Rule struct {
+	Name           string `json:"name" yaml:"name"`
+	PathGlob       string `json:"path_glob,omitempty" yaml:"path_glob,omitempty"`
+	Language       string `json:"language,omitempty" yaml:"language,omitempty"`
+	ContentPattern string `json:"content_pattern,omitempty" yaml:"content_pattern,omitempty"`
+	PromptTemplate string `json:"prompt_template,omitempty" yaml:"prompt_template,omitempty"`
+	Provider       string `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Model          string `json:"model,omitempty" yaml:"model,omitempty"`
+	MinSeverity    string `json:"min_severity,omitempty" yaml:"min_severity,omitempty"`
+
+	contentRe *regexp.Regexp
+}
+
+// compile precomputes the Rule's ContentPattern regexp.
+func (r *Rule) compile() error {
+	if r.ContentPattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(r.ContentPattern)
+	if err != nil {
+		return fmt.Errorf("rules: rule %q: compile content_pattern: %w", r.Name, err)
+	}
+	r.contentRe = re
+	return nil
+}
+
+// matches reports whether file satisfies every matcher field set on r.
+func (r Rule) matches(file reviewer.FileInput) bool {
+	if r.PathGlob != "" {
+		ok, err := path.Match(r.PathGlob, file.Path)
+		if err != nil || !ok {
+			return false
+		}
+	}
+	if r.Language != "" && !strings.EqualFold(r.Language, file.Language) {
+		return false
+	}
+	if r.contentRe != nil && !r.contentRe.MatchString(file.Content) {
+		return false
+	}
+	return true
+}
+
+// Set is an ordered collection of Rules. When several match the same file,
+// they form a chain applied in order, so a later rule's non-empty fields
+// override an earlier rule's.
+type Set struct {
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// Match returns the rules in s that match file, in declaration order.
+func (s Set) Match(file reviewer.FileInput) []Rule {
+	var matched []Rule
+	for _, r := range s.Rules {
+		if r.matches(file) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// compile precomputes every rule's content regexp.
+func (s Set) compile() error {
+	for i := range s.Rules {
+		if err := s.Rules[i].compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var severityRank = map[reviewer.Severity]int{
+	reviewer.SeverityInfo:     0,
+	reviewer.SeverityWarning:  1,
+	reviewer.SeverityError:    2,
+	reviewer.SeverityCritical: 3,
+}
+
+// meetsThreshold reports whether sev is at least as severe as min. An
+// empty or unrecognized min imposes no threshold.
+func meetsThreshold(sev reviewer.Severity, min reviewer.Severity) bool {
+	minRank, ok := severityRank[min]
+	if !ok {
+		return true
+	}
+	return severityRank[sev] >= minRank
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/lemon-mint/autocritic/internal/reviewer"
+)
+
+func TestSetMatchPathGlob(t *testing.T) {
+	set := Set{Rules: []Rule{
+		{Name: "python", PathGlob: "*.py"},
+		{Name: "go", PathGlob: "*.go"},
+	}}
+	if err := set.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	matched := set.Match(reviewer.FileInput{Path: "main.py"})
+	if len(matched) != 1 || matched[0].Name != "python" {
+		t.Fatalf("expected only the python rule to match, got %v", matched)
+	}
+}
+
+func TestSetMatchLanguageIsCaseInsensitive(t *testing.T) {
+	set := Set{Rules: []Rule{{Name: "go", Language: "Go"}}}
+	if err := set.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	matched := set.Match(reviewer.FileInput{Path: "main.go", Language: "go"})
+	if len(matched) != 1 {
+		t.Fatalf("expected language match regardless of case, got %v", matched)
+	}
+}
+
+func TestSetMatchContentPattern(t *testing.T) {
+	set := Set{Rules: []Rule{{Name: "secrets", ContentPattern: `(?i)api[_-]?key`}}}
+	if err := set.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	if matched := set.Match(reviewer.FileInput{Path: "a.txt", Content: "no secrets here"}); len(matched) != 0 {
+		t.Fatalf("expected no match, got %v", matched)
+	}
+	if matched := set.Match(reviewer.FileInput{Path: "a.txt", Content: "API_KEY=xyz"}); len(matched) != 1 {
+		t.Fatalf("expected content pattern to match, got %v", matched)
+	}
+}
+
+func TestSetMatchRequiresAllFields(t *testing.T) {
+	set := Set{Rules: []Rule{{Name: "go-secrets", PathGlob: "*.go", ContentPattern: "TODO"}}}
+	if err := set.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	if matched := set.Match(reviewer.FileInput{Path: "main.go", Content: "no markers"}); len(matched) != 0 {
+		t.Fatalf("expected no match when content_pattern doesn't match, got %v", matched)
+	}
+	if matched := set.Match(reviewer.FileInput{Path: "main.py", Content: "TODO: fix"}); len(matched) != 0 {
+		t.Fatalf("expected no match when path_glob doesn't match, got %v", matched)
+	}
+}
+
+func TestSetMatchOrdersByDeclaration(t *testing.T) {
+	set := Set{Rules: []Rule{
+		{Name: "first", PathGlob: "*.go"},
+		{Name: "second", PathGlob: "*.go"},
+	}}
+	if err := set.compile(); err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+
+	matched := set.Match(reviewer.FileInput{Path: "main.go"})
+	if len(matched) != 2 || matched[0].Name != "first" || matched[1].Name != "second" {
+		t.Fatalf("expected rules in declaration order, got %v", matched)
+	}
+}
+
+func TestMeetsThreshold(t *testing.T) {
+	tests := []struct {
+		name string
+		sev  reviewer.Severity
+		min  reviewer.Severity
+		want bool
+	}{
+		{"above threshold passes", reviewer.SeverityError, reviewer.SeverityWarning, true},
+		{"below threshold fails", reviewer.SeverityInfo, reviewer.SeverityWarning, false},
+		{"equal threshold passes", reviewer.SeverityWarning, reviewer.SeverityWarning, true},
+		{"empty min imposes no threshold", reviewer.SeverityInfo, "", true},
+		{"unrecognized min imposes no threshold", reviewer.SeverityInfo, reviewer.Severity("bogus"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := meetsThreshold(tt.sev, tt.min); got != tt.want {
+				t.Fatalf("meetsThreshold(%q, %q) = %v, want %v", tt.sev, tt.min, got, tt.want)
+			}
+		})
+	}
+}
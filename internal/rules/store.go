@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Store holds the currently loaded rule Set and knows how to reload it
+// from disk, so operators can update rules without restarting the process.
+type Store struct {
+	path string
+
+	mu  sync.RWMutex
+	set Set
+}
+
+// LoadStoreFile reads a YAML or JSON rules file (selected by extension)
+// into a new Store.
+func LoadStoreFile(path string) (*Store, error) {
+	s := &Store{path: path}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload re-reads the rules file from disk and atomically swaps it in.
+// A malformed file leaves the previously loaded Set in place.
+func (s *Store) Reload() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("rules: read %s: %w", s.path, err)
+	}
+
+	var set Set
+	switch ext := strings.ToLower(filepath.Ext(s.path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &set)
+	case ".json":
+		err = json.Unmarshal(data, &set)
+	default:
+		return fmt.Errorf("rules: unsupported rules file extension %q", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("rules: parse %s: %w", s.path, err)
+	}
+	if err := set.compile(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.set = set
+	s.mu.Unlock()
+	return nil
+}
+
+// Current returns the currently loaded Set.
+func (s *Store) Current() Set {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set
+}
+
+// WatchSIGHUP reloads the store whenever the process receives SIGHUP,
+// logging the outcome. It returns immediately; reloading happens in a
+// background goroutine for the lifetime of the process.
+func (s *Store) WatchSIGHUP() {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for range sighup {
+			if err := s.Reload(); err != nil {
+				log.Error().Err(err).Str("path", s.path).Msg("Failed to reload rules file")
+				continue
+			}
+			log.Info().Str("path", s.path).Msg("Reloaded rules file")
+		}
+	}()
+}
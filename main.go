@@ -5,30 +5,106 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/lemon-mint/autocritic/internal/auth"
+	"github.com/lemon-mint/autocritic/internal/metrics"
+	"github.com/lemon-mint/autocritic/internal/reviewer"
+	"github.com/lemon-mint/autocritic/internal/rules"
 )
 
-// CodeRequest represents the code to be sent to the AI service.
-type CodeRequest struct {
-	Code string `json:"code"`
+// reviewerConfigFromEnv builds a reviewer.Config from the environment.
+// AI_PROVIDER selects the backend ("openai", "anthropic", "gemini"); the
+// remaining AI_* variables are optional overrides.
+func reviewerConfigFromEnv() reviewer.Config {
+	cfg := reviewer.Config{
+		Provider: os.Getenv("AI_PROVIDER"),
+		BaseURL:  os.Getenv("AI_BASE_URL"),
+		Model:    os.Getenv("AI_MODEL"),
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = "openai"
+	}
+	cfg.APIKey = providerAPIKeyFromEnv(cfg.Provider)
+	if v := os.Getenv("AI_TIMEOUT_SECONDS"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			cfg.Timeout = time.Duration(secs) * time.Second
+		}
+	}
+	if v := os.Getenv("AI_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.MaxRetries = n
+		}
+	}
+	return cfg
+}
+
+// providerAPIKeyFromEnv resolves the credential for provider. OpenAI,
+// Anthropic, and Gemini take incompatible secrets, so AI_<PROVIDER>_API_KEY
+// (e.g. AI_ANTHROPIC_API_KEY) takes precedence when set; it falls back to
+// the generic AI_API_KEY so a single-provider deployment needs no extra
+// configuration.
+func providerAPIKeyFromEnv(provider string) string {
+	if v := os.Getenv("AI_" + strings.ToUpper(provider) + "_API_KEY"); v != "" {
+		return v
+	}
+	return os.Getenv("AI_API_KEY")
 }
 
-// CodeResponse represents the response from the AI service.
-type CodeResponse struct {
-	Feedback string `json:"feedback"`
+// introspectionAddrFromEnv returns the address the introspection server
+// listens on, defaulting to :6060.
+func introspectionAddrFromEnv() string {
+	if addr := os.Getenv("INTROSPECTION_ADDR"); addr != "" {
+		return addr
+	}
+	return ":6060"
 }
 
-// aiServiceMock simulates an AI service.
-func aiServiceMock(code string) (*CodeResponse, error) {
-	// Simulate some AI processing.
-	feedback := fmt.Sprintf("AI feedback: Your code is %s!", code)
-	return &CodeResponse{Feedback: feedback}, nil
+// signatureVerifierFromEnv builds a request-signing verifier from the
+// environment. Signing stays off by default so local dev is unaffected;
+// set AUTH_KEYS_FILE to enable it, and AUTH_REQUIRE_SIGNATURE=1 to reject
+// unsigned requests instead of merely verifying signed ones.
+func signatureVerifierFromEnv() (*auth.Verifier, error) {
+	keysFile := os.Getenv("AUTH_KEYS_FILE")
+	if keysFile == "" {
+		return nil, nil
+	}
+
+	keys, err := auth.LoadKeyStoreFile(keysFile)
+	if err != nil {
+		return nil, fmt.Errorf("load signing keys: %w", err)
+	}
+
+	verifier := auth.NewVerifier(keys)
+	verifier.Required = os.Getenv("AUTH_REQUIRE_SIGNATURE") == "1"
+	return verifier, nil
+}
+
+// rulesStoreFromEnv loads the review policy rules file named by
+// RULES_FILE, if set, and arranges for it to be hot-reloaded on SIGHUP.
+func rulesStoreFromEnv() (*rules.Store, error) {
+	rulesFile := os.Getenv("RULES_FILE")
+	if rulesFile == "" {
+		return nil, nil
+	}
+
+	store, err := rules.LoadStoreFile(rulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("load rules file: %w", err)
+	}
+	store.WatchSIGHUP()
+	return store, nil
 }
 
 func main() {
@@ -36,10 +112,86 @@ func main() {
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 
+	rev, err := reviewer.New(reviewerConfigFromEnv())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure AI reviewer")
+	}
+
+	verifier, err := signatureVerifierFromEnv()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure request signature verifier")
+	}
+
+	rulesStore, err := rulesStoreFromEnv()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to configure review policy rules")
+	}
+	if rulesStore != nil {
+		rev = rules.NewDispatcher(rulesStore, reviewerConfigFromEnv(), rev, providerAPIKeyFromEnv)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	apiSrv := &http.Server{Addr: ":8080", Handler: newCodeMux(rev, verifier)}
+	introspectionSrv := &http.Server{Addr: introspectionAddrFromEnv(), Handler: newIntrospectionMux(rulesStore)}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return serveUntilCanceled(gctx, apiSrv, "code API") })
+	g.Go(func() error { return serveUntilCanceled(gctx, introspectionSrv, "introspection") })
+
+	if err := g.Wait(); err != nil {
+		log.Error().Err(err).Msg("Server group exited with error")
+	}
+}
+
+// newCodeMux builds the mux serving the public /code review API. When
+// verifier is non-nil, requests are passed through HMAC signature
+// verification before reaching the handler. Instrumentation wraps the
+// verifier, not the other way around, so requests it rejects still show up
+// in CodeRequestsTotal/CodeRequestDuration/CodeRequestsInFlight instead of
+// vanishing from operator visibility.
+func newCodeMux(rev reviewer.Reviewer, verifier *auth.Verifier) *http.ServeMux {
+	mux := http.NewServeMux()
+	handler := http.Handler(codeHandler(rev))
+	if verifier != nil {
+		handler = verifier.Wrap(handler)
+	}
+	mux.Handle("/code", instrumentCodeHandler(handler.ServeHTTP))
+	return mux
+}
+
+// newIntrospectionMux builds the mux serving operator-facing endpoints:
+// Prometheus metrics, liveness/readiness probes, pprof profiles, and (when
+// rulesStore is non-nil) the currently loaded review policy rules.
+func newIntrospectionMux(rulesStore *rules.Store) *http.ServeMux {
 	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	if rulesStore != nil {
+		mux.HandleFunc("/rules", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(rulesStore.Current())
+		})
+	}
+	return mux
+}
 
-	// Handle POST requests to /code
-	mux.HandleFunc("/code", func(w http.ResponseWriter, r *http.Request) {
+// codeHandler handles POST /code: it decodes a CodeRequest and, depending
+// on the client's Accept header, either streams incremental review events
+// or returns the buffered JSON CodeResponse.
+func codeHandler(rev reviewer.Reviewer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			return
@@ -47,61 +199,164 @@ func main() {
 
 		// Use a decoder to parse the JSON stream directly.
 		decoder := json.NewDecoder(r.Body)
-		var req CodeRequest
+		var req reviewer.CodeRequest
 		if err := decoder.Decode(&req); err != nil {
 			log.Error().Err(err).Msg("Failed to decode request body")
 			http.Error(w, "Invalid request body", http.StatusBadRequest)
 			return
 		}
 
-		// Send the code to the AI service.
-		resp, err := aiServiceMock(req.Code)
-		if err != nil {
-			log.Error().Err(err).Msg("Error calling AI service")
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
+		// Clients that ask for SSE or NDJSON get incremental review
+		// events as the upstream LLM produces them; everyone else gets
+		// the existing buffered JSON response.
+		switch accept := r.Header.Get("Accept"); {
+		case strings.Contains(accept, "text/event-stream"):
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			streamReview(w, r, rev, req, encodeSSE)
+		case strings.Contains(accept, "application/x-ndjson"):
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			streamReview(w, r, rev, req, encodeNDJSON)
+		default:
+			// Send the code to the AI reviewer.
+			resp, err := rev.Review(r.Context(), req)
+			if err != nil {
+				log.Error().Err(err).Msg("Error calling AI reviewer")
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			// Marshal the response and send it back.
+			jsonResp, err := json.Marshal(resp)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to marshal response")
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(jsonResp)
 		}
+	}
+}
 
-		// Marshal the response and send it back.
-		jsonResp, err := json.Marshal(resp)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to marshal response")
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
-			return
+// instrumentCodeHandler wraps next with the request-count, latency
+// histogram, and in-flight gauge metrics for the /code handler.
+func instrumentCodeHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		metrics.CodeRequestsInFlight.Inc()
+		defer metrics.CodeRequestsInFlight.Dec()
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r)
+		metrics.CodeRequestDuration.Observe(time.Since(start).Seconds())
+
+		outcome := "ok"
+		if rec.status >= 400 {
+			outcome = "error"
 		}
+		metrics.CodeRequestsTotal.WithLabelValues(outcome).Inc()
+	}
+}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.Write(jsonResp)
-	})
+// statusRecorder captures the status code written to an http.ResponseWriter
+// while still forwarding http.Flusher so streaming handlers keep working.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.status = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// streamReview drives req through rev as a StreamingReviewer, writing each
+// ReviewEvent with encode and flushing after every one. r.Context() is
+// wired into the review call, so a client disconnect aborts the upstream
+// request instead of leaking it.
+func streamReview(w http.ResponseWriter, r *http.Request, rev reviewer.Reviewer, req reviewer.CodeRequest, encode func(http.ResponseWriter, reviewer.ReviewEvent) error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := reviewer.AsStreaming(rev).ReviewStream(r.Context(), req)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to start streaming review")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := encode(w, event); err != nil {
+				log.Error().Err(err).Msg("Failed to write review event")
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
 
-	// Create a server with a custom shutdown handler
-	srv := &http.Server{
-		Addr:    ":8080",
-		Handler: mux,
+func encodeSSE(w http.ResponseWriter, event reviewer.ReviewEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
 	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, payload)
+	return err
+}
 
-	stopChan := make(chan os.Signal, 1)
-	signal.Notify(stopChan, os.Interrupt, syscall.SIGTERM)
+func encodeNDJSON(w http.ResponseWriter, event reviewer.ReviewEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(payload, '\n'))
+	return err
+}
 
-	// Start the server
+// serveUntilCanceled runs srv until ctx is canceled, then shuts it down
+// gracefully with a 5-second deadline. It returns any error encountered
+// starting or shutting down the server.
+func serveUntilCanceled(ctx context.Context, srv *http.Server, name string) error {
+	errCh := make(chan error, 1)
 	go func() {
-		log.Info().Msg("Server listening on port 8080...")
+		log.Info().Str("server", name).Str("addr", srv.Addr).Msg("Server listening...")
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal().Err(err).Msg("Error starting server")
+			errCh <- err
+			return
 		}
+		errCh <- nil
 	}()
 
-	// Wait for a signal to shut down
-	<-stopChan
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
 
-	// Create a 5-second timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Shutdown the server
-	log.Info().Msg("Shutting down server...")
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Error().Err(err).Msg("Error shutting down server")
+	log.Info().Str("server", name).Msg("Shutting down server...")
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
 	}
-	log.Info().Msg("Server shut down successfully.")
+	return <-errCh
 }